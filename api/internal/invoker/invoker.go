@@ -0,0 +1,12 @@
+package invoker
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Logger is the process-wide structured logger, wired up during application bootstrap
+var Logger *zap.Logger
+
+// Db is the process-wide MySQL connection, wired up during application bootstrap
+var Db *gorm.DB
@@ -0,0 +1,156 @@
+package containerd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/events"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/typeurl"
+
+	"github.com/gotomicro/ego/core/elog"
+
+	"github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/manager"
+)
+
+const (
+	clientType = "containerd"
+	// k8sNamespace is the namespace kubelet/cri-containerd runs pod containers under
+	k8sNamespace = "k8s.io"
+)
+
+func init() {
+	manager.Register(clientType, &client{})
+}
+
+// client talks to containerd over its task/container API via containerd.sock
+type client struct {
+	cfg *manager.Config
+	cli *containerd.Client
+}
+
+func (c *client) Run(cfg *manager.Config) error {
+	c.cfg = cfg
+	cli, err := containerd.New(cfg.ClientSocket, containerd.WithTimeout(cfg.RequestTimeout))
+	if err != nil {
+		return fmt.Errorf("containerd: connect to %s failed, %w", cfg.ClientSocket, err)
+	}
+	c.cli = cli
+	return nil
+}
+
+func (c *client) GetAllDockerInfo() (map[string]*manager.DockerInfo, error) {
+	ctx := namespaces.WithNamespace(context.Background(), k8sNamespace)
+	containers, err := c.cli.Containers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("containerd: list containers failed, %w", err)
+	}
+	res := make(map[string]*manager.DockerInfo, len(containers))
+	for _, container := range containers {
+		info, err := container.Info(ctx)
+		if err != nil {
+			continue
+		}
+		res[container.ID()] = &manager.DockerInfo{
+			ContainerId: container.ID(),
+			Name:        info.Labels["io.kubernetes.container.name"],
+			Image:       info.Image,
+			Labels:      info.Labels,
+		}
+	}
+	return res, nil
+}
+
+var topicToAction = map[string]manager.Action{
+	"/tasks/create": manager.ActionCreate,
+	"/tasks/start":  manager.ActionStart,
+	"/tasks/exit":   manager.ActionDie,
+	"/tasks/delete": manager.ActionDestroy,
+}
+
+// Subscribe streams task lifecycle events off the containerd event service
+func (c *client) Subscribe(ctx context.Context) (<-chan manager.ContainerEvent, error) {
+	ctx = namespaces.WithNamespace(ctx, k8sNamespace)
+	evtCh, errCh := c.cli.EventService().Subscribe(ctx,
+		`topic=="/tasks/create"`, `topic=="/tasks/start"`, `topic=="/tasks/exit"`, `topic=="/tasks/delete"`)
+
+	out := make(chan manager.ContainerEvent)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errCh:
+				if err != nil {
+					elog.Error("containerd: events stream failed", elog.FieldErr(err))
+				}
+				return
+			case envelope, ok := <-evtCh:
+				if !ok {
+					return
+				}
+				action, known := topicToAction[envelope.Topic]
+				if !known {
+					continue
+				}
+				containerID := c.containerIDFromEnvelope(envelope)
+				if containerID == "" {
+					continue
+				}
+				ce := manager.ContainerEvent{Action: action, ContainerId: containerID}
+				if action != manager.ActionDestroy {
+					if info, err := c.containerDockerInfo(ctx, containerID); err == nil {
+						ce.Info = info
+					}
+				}
+				select {
+				case out <- ce:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// containerIDFromEnvelope unwraps the typeurl.Any payload for the task events we subscribe
+// to, all of which carry a ContainerID field, and returns "" for anything unexpected.
+func (c *client) containerIDFromEnvelope(envelope *events.Envelope) string {
+	v, err := typeurl.UnmarshalAny(envelope.Event)
+	if err != nil {
+		return ""
+	}
+	switch e := v.(type) {
+	case *apievents.TaskCreate:
+		return e.ContainerID
+	case *apievents.TaskStart:
+		return e.ContainerID
+	case *apievents.TaskExit:
+		return e.ContainerID
+	case *apievents.TaskDelete:
+		return e.ContainerID
+	default:
+		return ""
+	}
+}
+
+func (c *client) containerDockerInfo(ctx context.Context, id string) (*manager.DockerInfo, error) {
+	container, err := c.cli.LoadContainer(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("containerd: load container %s failed, %w", id, err)
+	}
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("containerd: info for %s failed, %w", id, err)
+	}
+	return &manager.DockerInfo{
+		ContainerId: id,
+		Name:        info.Labels["io.kubernetes.container.name"],
+		Image:       info.Image,
+		Labels:      info.Labels,
+	}, nil
+}
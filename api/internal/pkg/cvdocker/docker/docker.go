@@ -0,0 +1,190 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gotomicro/ego/core/elog"
+
+	"github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/manager"
+)
+
+const clientType = "docker"
+
+func init() {
+	manager.Register(clientType, &client{})
+}
+
+// client speaks the Docker Engine REST API over the docker.sock unix socket
+type client struct {
+	cfg        *manager.Config
+	httpClient *http.Client
+	// streamClient is used only for the long-lived /events request. It shares httpClient's
+	// unix-socket transport but carries no Timeout, since http.Client.Timeout bounds the
+	// whole request including reading the body - ctx (via http.NewRequestWithContext) is
+	// the sole cancellation mechanism for the stream instead.
+	streamClient *http.Client
+}
+
+func (c *client) Run(cfg *manager.Config) error {
+	c.cfg = cfg
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", cfg.ClientSocket)
+		},
+	}
+	c.httpClient = &http.Client{
+		Timeout:   cfg.RequestTimeout,
+		Transport: transport,
+	}
+	c.streamClient = &http.Client{Transport: transport}
+	return nil
+}
+
+type containerSummary struct {
+	Id     string            `json:"Id"`
+	Names  []string          `json:"Names"`
+	Image  string            `json:"Image"`
+	Labels map[string]string `json:"Labels"`
+}
+
+type containerInspect struct {
+	LogPath string `json:"LogPath"`
+	Config  struct {
+		Env []string `json:"Env"`
+	} `json:"Config"`
+}
+
+func (c *client) GetAllDockerInfo() (map[string]*manager.DockerInfo, error) {
+	summaries, err := c.listContainers()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*manager.DockerInfo, len(summaries))
+	for _, s := range summaries {
+		info := &manager.DockerInfo{
+			ContainerId: s.Id,
+			Image:       s.Image,
+			Labels:      s.Labels,
+		}
+		if len(s.Names) > 0 {
+			info.Name = s.Names[0]
+		}
+		if inspect, iErr := c.inspectContainer(s.Id); iErr == nil {
+			info.LogPath = inspect.LogPath
+			info.Env = inspect.Config.Env
+		}
+		res[s.Id] = info
+	}
+	return res, nil
+}
+
+func (c *client) listContainers() ([]containerSummary, error) {
+	resp, err := c.httpClient.Get("http://unix/containers/json?all=false")
+	if err != nil {
+		return nil, fmt.Errorf("docker: list containers failed, %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("docker: list containers failed, status %d", resp.StatusCode)
+	}
+	var summaries []containerSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summaries); err != nil {
+		return nil, fmt.Errorf("docker: decode container list failed, %w", err)
+	}
+	return summaries, nil
+}
+
+func (c *client) inspectContainer(id string) (*containerInspect, error) {
+	resp, err := c.httpClient.Get(fmt.Sprintf("http://unix/containers/%s/json", id))
+	if err != nil {
+		return nil, fmt.Errorf("docker: inspect container %s failed, %w", id, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("docker: inspect container %s failed, status %d", id, resp.StatusCode)
+	}
+	var inspect containerInspect
+	if err := json.NewDecoder(resp.Body).Decode(&inspect); err != nil {
+		return nil, fmt.Errorf("docker: decode inspect for %s failed, %w", id, err)
+	}
+	return &inspect, nil
+}
+
+// dockerEvent is the subset of the Docker Engine /events payload we care about
+type dockerEvent struct {
+	Status string `json:"status"`
+	Id     string `json:"id"`
+	Type   string `json:"Type"`
+}
+
+var statusToAction = map[string]manager.Action{
+	"create":  manager.ActionCreate,
+	"start":   manager.ActionStart,
+	"die":     manager.ActionDie,
+	"destroy": manager.ActionDestroy,
+}
+
+// Subscribe streams container lifecycle events off the Docker Engine /events endpoint
+func (c *client) Subscribe(ctx context.Context) (<-chan manager.ContainerEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://unix/events?filters={\"type\":[\"container\"]}", nil)
+	if err != nil {
+		return nil, fmt.Errorf("docker: build events request failed, %w", err)
+	}
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("docker: subscribe to events failed, %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("docker: subscribe to events failed, status %d", resp.StatusCode)
+	}
+
+	out := make(chan manager.ContainerEvent)
+	go func() {
+		defer resp.Body.Close()
+		defer close(out)
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var evt dockerEvent
+			if err := dec.Decode(&evt); err != nil {
+				if ctx.Err() == nil {
+					elog.Error("docker: events stream decode failed", elog.FieldErr(err))
+				}
+				return
+			}
+			action, ok := statusToAction[evt.Status]
+			if !ok {
+				continue
+			}
+			ce := manager.ContainerEvent{Action: action, ContainerId: evt.Id}
+			if action != manager.ActionDestroy {
+				if info, err := c.inspectAsDockerInfo(evt.Id); err == nil {
+					ce.Info = info
+				}
+			}
+			select {
+			case out <- ce:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (c *client) inspectAsDockerInfo(id string) (*manager.DockerInfo, error) {
+	inspect, err := c.inspectContainer(id)
+	if err != nil {
+		return nil, err
+	}
+	return &manager.DockerInfo{
+		ContainerId: id,
+		LogPath:     inspect.LogPath,
+		Env:         inspect.Config.Env,
+	}, nil
+}
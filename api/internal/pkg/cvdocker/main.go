@@ -1,57 +1,189 @@
 package cvdocker
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
 	_ "github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/containerd"
 	_ "github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/docker"
 	"github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/manager"
+	_ "github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/podman"
 	"github.com/clickvisual/clickvisual/api/internal/pkg/utils"
 	"github.com/gotomicro/ego/core/elog"
 )
 
-const containerdSock = "/run/containerd/containerd.sock"
-const dockerSock = "/var/run/docker.sock"
+const (
+	containerdSock = "/run/containerd/containerd.sock"
+	dockerSock     = "/var/run/docker.sock"
+	// podmanSockRootful is where the podman.service system unit publishes its API socket
+	podmanSockRootful = "/run/podman/podman.sock"
+	// podmanSockRootlessFallback is used when $XDG_RUNTIME_DIR isn't set for the current user
+	podmanSockRootlessFallback = "/run/user/%d/podman/podman.sock"
+)
+
+// runtime is one container-engine socket this host exposes, paired with its already-dialed client
+type runtime struct {
+	clientType string // docker, containerd, podman
+	client     manager.Client
+}
 
 // Component 组件
 type Component struct {
-	config       *manager.Config
+	runtimes []runtime
+
+	mu           sync.RWMutex
 	containerMap map[string]*manager.DockerInfo
-	clientType   string // docker, containerd
 }
 
 func NewContainer() *Component {
-	obj := &Component{
-		config: manager.DefaultConfig(),
-	}
-	isExistDockerSock, err := utils.PathExist(dockerSock)
-	if err != nil {
+	obj := &Component{}
+
+	if isExist, err := utils.PathExist(dockerSock); err != nil {
 		elog.Panic("docker sock fail", elog.FieldErr(err))
+	} else if isExist {
+		obj.addRuntime("docker", dockerSock)
 	}
 
-	isExistContainerdSock, err := utils.PathExist(containerdSock)
-	if err != nil {
+	if isExist, err := utils.PathExist(containerdSock); err != nil {
 		elog.Panic("containerd sock fail", elog.FieldErr(err))
+	} else if isExist {
+		obj.addRuntime("containerd", containerdSock)
 	}
 
-	if !isExistDockerSock && !isExistContainerdSock {
-		elog.Panic("docker.sock and containerd.sock is empty", elog.FieldValue("we need "+containerdSock+" or "+dockerSock))
+	if sock, isExist := locatePodmanSock(); isExist {
+		obj.addRuntime("podman", sock)
 	}
-	if isExistContainerdSock {
-		obj.config.ClientSocket = containerdSock
-		obj.clientType = "containerd"
-	} else if isExistDockerSock {
-		obj.config.ClientSocket = dockerSock
-		obj.clientType = "docker"
+
+	if len(obj.runtimes) == 0 {
+		elog.Panic("no container runtime socket found", elog.FieldValue(fmt.Sprintf(
+			"we need one of %s, %s, %s or %s", dockerSock, containerdSock, podmanSockRootful, podmanSockRootlessFallback,
+		)))
 	}
+
+	obj.scanAll()
 	return obj
 }
 
-func (c *Component) GetActiveContainers() (containerMap map[string]*manager.DockerInfo) {
-	var err error
-	obj := manager.Get(c.clientType)
-	obj.Run(c.config)
-	containerMap, err = obj.GetAllDockerInfo()
-	if err != nil {
-		elog.Panic("containerdSockObj fetchAll fail", elog.FieldErr(err))
+func (c *Component) addRuntime(clientType, socket string) {
+	cli := manager.Get(clientType)
+	cfg := manager.DefaultConfig()
+	cfg.ClientSocket = socket
+	if err := cli.Run(cfg); err != nil {
+		elog.Panic(clientType+" client run fail", elog.FieldErr(err))
+	}
+	c.runtimes = append(c.runtimes, runtime{clientType: clientType, client: cli})
+}
+
+// locatePodmanSock probes the rootful podman.sock first, then falls back to the
+// rootless per-user socket under $XDG_RUNTIME_DIR (or /run/user/<uid> if unset).
+func locatePodmanSock() (string, bool) {
+	if isExist, err := utils.PathExist(podmanSockRootful); err != nil {
+		elog.Error("podman sock fail", elog.FieldErr(err))
+	} else if isExist {
+		return podmanSockRootful, true
+	}
+
+	rootlessSock := fmt.Sprintf(podmanSockRootlessFallback, os.Getuid())
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		rootlessSock = runtimeDir + "/podman/podman.sock"
+	}
+	if isExist, err := utils.PathExist(rootlessSock); err != nil {
+		elog.Error("podman sock fail", elog.FieldErr(err))
+	} else if isExist {
+		return rootlessSock, true
+	}
+	return "", false
+}
+
+// scanAll enumerates containers across every runtime socket discovered on this host and
+// merges the results into containerMap, prefixing each key with its runtime's clientType
+// so a containerd container can never collide with a podman one sharing the same short ID.
+// A runtime that fails to report (e.g. a flaky Podman socket) is logged and skipped rather
+// than aborting the scan, so containers already merged from the other, healthy runtimes
+// are still kept.
+func (c *Component) scanAll() {
+	merged := make(map[string]*manager.DockerInfo)
+	for _, rt := range c.runtimes {
+		containerMap, err := rt.client.GetAllDockerInfo()
+		if err != nil {
+			elog.Error(rt.clientType+" fetchAll fail", elog.FieldErr(err))
+			continue
+		}
+		for id, info := range containerMap {
+			merged[rt.clientType+":"+id] = info
+		}
+	}
+	c.mu.Lock()
+	c.containerMap = merged
+	c.mu.Unlock()
+}
+
+// GetActiveContainers snapshots the in-memory container cache. The cache is seeded by
+// scanAll at construction time and, once Subscribe is running, kept current by the event
+// loop - callers never pay for a rescan here.
+func (c *Component) GetActiveContainers() map[string]*manager.DockerInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snapshot := make(map[string]*manager.DockerInfo, len(c.containerMap))
+	for id, info := range c.containerMap {
+		snapshot[id] = info
+	}
+	return snapshot
+}
+
+// Subscribe fans the lifecycle events of every runtime that supports them (docker,
+// containerd, podman all do) into a single channel, keeping containerMap in sync as
+// events arrive so GetActiveContainers stays cheap. The returned channel closes once ctx
+// is done and every runtime's own event channel has drained.
+func (c *Component) Subscribe(ctx context.Context) (<-chan manager.ContainerEvent, error) {
+	out := make(chan manager.ContainerEvent)
+	var wg sync.WaitGroup
+	var subscribed bool
+
+	for _, rt := range c.runtimes {
+		subscriber, ok := rt.client.(manager.EventSubscriber)
+		if !ok {
+			continue
+		}
+		evtCh, err := subscriber.Subscribe(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cvdocker: subscribe to %s events failed, %w", rt.clientType, err)
+		}
+		subscribed = true
+		wg.Add(1)
+		go c.forward(ctx, &wg, rt.clientType, evtCh, out)
+	}
+	if !subscribed {
+		close(out)
+		return out, fmt.Errorf("cvdocker: no discovered runtime supports event subscription")
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out, nil
+}
+
+func (c *Component) forward(ctx context.Context, wg *sync.WaitGroup, clientType string, in <-chan manager.ContainerEvent, out chan<- manager.ContainerEvent) {
+	defer wg.Done()
+	for evt := range in {
+		evt.ContainerId = clientType + ":" + evt.ContainerId
+
+		c.mu.Lock()
+		if evt.Action == manager.ActionDestroy {
+			delete(c.containerMap, evt.ContainerId)
+		} else if evt.Info != nil {
+			c.containerMap[evt.ContainerId] = evt.Info
+		}
+		c.mu.Unlock()
+
+		select {
+		case out <- evt:
+		case <-ctx.Done():
+			return
+		}
 	}
-	return
 }
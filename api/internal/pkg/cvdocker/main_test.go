@@ -0,0 +1,79 @@
+package cvdocker
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/manager"
+	"github.com/clickvisual/clickvisual/api/internal/pkg/utils"
+)
+
+// fakeClient is a manager.Client stand-in whose GetAllDockerInfo result is fixed at
+// construction, used to exercise scanAll without a real runtime socket.
+type fakeClient struct {
+	info map[string]*manager.DockerInfo
+	err  error
+}
+
+func (f *fakeClient) Run(*manager.Config) error { return nil }
+
+func (f *fakeClient) GetAllDockerInfo() (map[string]*manager.DockerInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.info, nil
+}
+
+func TestScanAll_MergesAcrossRuntimesAndSkipsFailingOnes(t *testing.T) {
+	healthy := &fakeClient{info: map[string]*manager.DockerInfo{"abc": {ContainerId: "abc"}}}
+	failing := &fakeClient{err: errors.New("flaky socket")}
+
+	c := &Component{runtimes: []runtime{
+		{clientType: "docker", client: healthy},
+		{clientType: "podman", client: failing},
+	}}
+	c.scanAll()
+
+	got := c.GetActiveContainers()
+	if len(got) != 1 {
+		t.Fatalf("GetActiveContainers() = %d entries, want 1 (failing runtime should be skipped, not panic)", len(got))
+	}
+	if info, ok := got["docker:abc"]; !ok || info.ContainerId != "abc" {
+		t.Errorf("expected docker:abc from the healthy runtime to be merged, got %v", got)
+	}
+}
+
+func TestLocatePodmanSock_UsesXDGRuntimeDirWhenSet(t *testing.T) {
+	if exist, _ := utils.PathExist(podmanSockRootful); exist {
+		t.Skip("rootful podman sock present on this host, skipping rootless precedence test")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	if err := os.MkdirAll(dir+"/podman", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	sockPath := dir + "/podman/podman.sock"
+	f, err := os.Create(sockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	got, ok := locatePodmanSock()
+	if !ok || got != sockPath {
+		t.Errorf("locatePodmanSock() = (%q, %v), want (%q, true)", got, ok, sockPath)
+	}
+}
+
+func TestLocatePodmanSock_NotFound(t *testing.T) {
+	if exist, _ := utils.PathExist(podmanSockRootful); exist {
+		t.Skip("rootful podman sock present on this host, skipping not-found test")
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	if _, ok := locatePodmanSock(); ok {
+		t.Errorf("locatePodmanSock() = true, want false when neither socket exists")
+	}
+}
@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Config the runtime-agnostic configuration used to dial a container engine socket
+type Config struct {
+	ClientSocket   string
+	RequestTimeout time.Duration
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		RequestTimeout: time.Second * 5,
+	}
+}
+
+// DockerInfo normalized container info, populated by docker/containerd/podman clients alike
+type DockerInfo struct {
+	ContainerId string
+	Name        string
+	Image       string
+	LogPath     string
+	Env         []string
+	Labels      map[string]string
+}
+
+// Client is implemented by every container-runtime backend (docker, containerd, podman, ...)
+type Client interface {
+	Run(cfg *Config) error
+	GetAllDockerInfo() (map[string]*DockerInfo, error)
+}
+
+// Action is the lifecycle transition a ContainerEvent reports
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionStart   Action = "start"
+	ActionDie     Action = "die"
+	ActionDestroy Action = "destroy"
+)
+
+// ContainerEvent is emitted by an EventSubscriber whenever a container's lifecycle changes.
+// Info is always nil for ActionDestroy, since the runtime no longer has anything to
+// describe, but it is best-effort for every other action too: the Inspect call backing it
+// can fail transiently, in which case the event is still delivered with Info left nil
+// rather than dropped or retried.
+type ContainerEvent struct {
+	Action      Action
+	ContainerId string
+	Info        *DockerInfo
+}
+
+// EventSubscriber is an optional capability a Client can implement to push lifecycle
+// events instead of being polled. Not every runtime backend needs to support it; callers
+// should type-assert for it and fall back to polling GetAllDockerInfo otherwise.
+type EventSubscriber interface {
+	Subscribe(ctx context.Context) (<-chan ContainerEvent, error)
+}
+
+var (
+	mu      sync.RWMutex
+	clients = make(map[string]Client)
+)
+
+// Register installs a Client under the given runtime name, it's meant to be called from init()
+func Register(name string, c Client) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exist := clients[name]; exist {
+		panic(fmt.Sprintf("cvdocker: manager client %s already registered", name))
+	}
+	clients[name] = c
+}
+
+// Get returns the Client registered under name, or nil if none is registered
+func Get(name string) Client {
+	mu.RLock()
+	defer mu.RUnlock()
+	return clients[name]
+}
@@ -0,0 +1,198 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gotomicro/ego/core/elog"
+
+	"github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/manager"
+)
+
+const clientType = "podman"
+
+func init() {
+	manager.Register(clientType, &client{})
+}
+
+// client speaks Podman's libpod REST API over the podman.sock unix socket
+// (rootful /run/podman/podman.sock or rootless $XDG_RUNTIME_DIR/podman/podman.sock)
+type client struct {
+	cfg        *manager.Config
+	httpClient *http.Client
+	// streamClient is used only for the long-lived /events request. It shares httpClient's
+	// unix-socket transport but carries no Timeout, since http.Client.Timeout bounds the
+	// whole request including reading the body - ctx (via http.NewRequestWithContext) is
+	// the sole cancellation mechanism for the stream instead.
+	streamClient *http.Client
+}
+
+func (c *client) Run(cfg *manager.Config) error {
+	c.cfg = cfg
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", cfg.ClientSocket)
+		},
+	}
+	c.httpClient = &http.Client{
+		Timeout:   cfg.RequestTimeout,
+		Transport: transport,
+	}
+	c.streamClient = &http.Client{Transport: transport}
+	return nil
+}
+
+type libpodContainerSummary struct {
+	Id     string            `json:"Id"`
+	Names  []string          `json:"Names"`
+	Image  string            `json:"Image"`
+	Labels map[string]string `json:"Labels"`
+}
+
+type libpodContainerInspect struct {
+	LogPath string `json:"LogPath"`
+	Config  struct {
+		Env []string `json:"Env"`
+	} `json:"Config"`
+}
+
+func (c *client) GetAllDockerInfo() (map[string]*manager.DockerInfo, error) {
+	summaries, err := c.listContainers()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*manager.DockerInfo, len(summaries))
+	for _, s := range summaries {
+		info := &manager.DockerInfo{
+			ContainerId: s.Id,
+			Image:       s.Image,
+			Labels:      s.Labels,
+		}
+		if len(s.Names) > 0 {
+			info.Name = s.Names[0]
+		}
+		if inspect, iErr := c.inspectContainer(s.Id); iErr == nil {
+			info.LogPath = inspect.LogPath
+			info.Env = inspect.Config.Env
+		}
+		res[s.Id] = info
+	}
+	return res, nil
+}
+
+func (c *client) listContainers() ([]libpodContainerSummary, error) {
+	resp, err := c.httpClient.Get("http://unix/libpod/containers/json?all=false")
+	if err != nil {
+		return nil, fmt.Errorf("podman: list containers failed, %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("podman: list containers failed, status %d", resp.StatusCode)
+	}
+	var summaries []libpodContainerSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summaries); err != nil {
+		return nil, fmt.Errorf("podman: decode container list failed, %w", err)
+	}
+	return summaries, nil
+}
+
+func (c *client) inspectContainer(id string) (*libpodContainerInspect, error) {
+	resp, err := c.httpClient.Get(fmt.Sprintf("http://unix/libpod/containers/%s/json", id))
+	if err != nil {
+		return nil, fmt.Errorf("podman: inspect container %s failed, %w", id, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("podman: inspect container %s failed, status %d", id, resp.StatusCode)
+	}
+	var inspect libpodContainerInspect
+	if err := json.NewDecoder(resp.Body).Decode(&inspect); err != nil {
+		return nil, fmt.Errorf("podman: decode inspect for %s failed, %w", id, err)
+	}
+	return &inspect, nil
+}
+
+// libpodEvent is the subset of the libpod /events payload we care about
+type libpodEvent struct {
+	Status string `json:"Status"`
+	Type   string `json:"Type"`
+	Actor  struct {
+		ID string `json:"ID"`
+	} `json:"Actor"`
+}
+
+var statusToAction = map[string]manager.Action{
+	"create":  manager.ActionCreate,
+	"start":   manager.ActionStart,
+	"died":    manager.ActionDie,
+	"remove":  manager.ActionDestroy,
+	"cleanup": manager.ActionDestroy,
+}
+
+// Subscribe streams container lifecycle events off Podman's libpod /events endpoint
+func (c *client) Subscribe(ctx context.Context) (<-chan manager.ContainerEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		`http://unix/libpod/events?stream=true&filters={"type":["container"]}`, nil)
+	if err != nil {
+		return nil, fmt.Errorf("podman: build events request failed, %w", err)
+	}
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("podman: subscribe to events failed, %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("podman: subscribe to events failed, status %d", resp.StatusCode)
+	}
+
+	out := make(chan manager.ContainerEvent)
+	go func() {
+		defer resp.Body.Close()
+		defer close(out)
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var evt libpodEvent
+			if err := dec.Decode(&evt); err != nil {
+				if ctx.Err() == nil {
+					elog.Error("podman: events stream decode failed", elog.FieldErr(err))
+				}
+				return
+			}
+			if evt.Type != "container" {
+				continue
+			}
+			action, ok := statusToAction[evt.Status]
+			if !ok {
+				continue
+			}
+			ce := manager.ContainerEvent{Action: action, ContainerId: evt.Actor.ID}
+			if action != manager.ActionDestroy {
+				if info, err := c.inspectAsDockerInfo(evt.Actor.ID); err == nil {
+					ce.Info = info
+				}
+			}
+			select {
+			case out <- ce:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (c *client) inspectAsDockerInfo(id string) (*manager.DockerInfo, error) {
+	inspect, err := c.inspectContainer(id)
+	if err != nil {
+		return nil, err
+	}
+	return &manager.DockerInfo{
+		ContainerId: id,
+		LogPath:     inspect.LogPath,
+		Env:         inspect.Config.Env,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package cvdocker
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/clickvisual/clickvisual/api/internal/pkg/cvdocker/manager"
+)
+
+func TestForward_DestroyRemovesAndMissingInfoLeavesCacheUntouched(t *testing.T) {
+	c := &Component{containerMap: map[string]*manager.DockerInfo{
+		"docker:existing": {ContainerId: "existing"},
+	}}
+
+	in := make(chan manager.ContainerEvent, 3)
+	in <- manager.ContainerEvent{Action: manager.ActionStart, ContainerId: "new", Info: &manager.DockerInfo{ContainerId: "new"}}
+	in <- manager.ContainerEvent{Action: manager.ActionDie, ContainerId: "new"} // best-effort inspect failed: Info nil
+	in <- manager.ContainerEvent{Action: manager.ActionDestroy, ContainerId: "existing"}
+	close(in)
+
+	out := make(chan manager.ContainerEvent, 3)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	c.forward(context.Background(), &wg, "docker", in, out)
+	wg.Wait()
+	close(out)
+	for range out {
+	}
+
+	got := c.GetActiveContainers()
+	if _, ok := got["docker:existing"]; ok {
+		t.Errorf("expected docker:existing to be removed after ActionDestroy")
+	}
+	if info, ok := got["docker:new"]; !ok || info.ContainerId != "new" {
+		t.Errorf("expected docker:new from the start event to survive a later die event with nil Info, got %v", got)
+	}
+}
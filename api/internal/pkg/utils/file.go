@@ -0,0 +1,15 @@
+package utils
+
+import "os"
+
+// PathExist reports whether the given path exists on disk.
+func PathExist(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
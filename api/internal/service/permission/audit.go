@@ -0,0 +1,99 @@
+package permission
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+// decisionBufferSize bounds each subscriber's channel; once full, publishDecision drops
+// the oldest buffered Decision rather than blocking the Check call that produced it
+const decisionBufferSize = 256
+
+// Decision is published for every terminal return of a UserPmsChecker.Check call
+type Decision struct {
+	UserId      int
+	ObjectType  string
+	ObjectIdx   string
+	SubResource string
+	Acts        []string
+	DomainType  string
+	DomainId    string
+	Allowed     bool
+	Reason      string
+	Latency     time.Duration
+	CheckerName string
+}
+
+var (
+	decisionSubsMu sync.RWMutex
+	decisionSubs   = make(map[chan Decision]struct{})
+)
+
+// Subscribe returns a channel of every Decision published from here on, until ctx is
+// done. The channel is closed once ctx is done; callers must keep draining it or risk
+// missing events once the bounded buffer fills (oldest dropped first).
+func Subscribe(ctx context.Context) <-chan Decision {
+	ch := make(chan Decision, decisionBufferSize)
+
+	decisionSubsMu.Lock()
+	decisionSubs[ch] = struct{}{}
+	decisionSubsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		decisionSubsMu.Lock()
+		delete(decisionSubs, ch)
+		decisionSubsMu.Unlock()
+		close(ch)
+	}()
+	return ch
+}
+
+// publishDecision fans d out to every live subscriber with drop-oldest semantics, so a
+// slow consumer (a laggy Kafka sink, say) can never block a permission check.
+func publishDecision(d Decision) {
+	decisionSubsMu.RLock()
+	defer decisionSubsMu.RUnlock()
+	for ch := range decisionSubs {
+		select {
+		case ch <- d:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- d:
+			default:
+			}
+		}
+	}
+}
+
+// recordDecision publishes a Decision describing the outcome of a Check call and returns
+// err unchanged, so callers can wire it up with a single defer line:
+//
+//	defer func() { err = recordDecision("default", reqPms, started, err) }()
+func recordDecision(checkerName string, reqPms view.ReqPermission, started time.Time, err error) error {
+	reason := "allowed"
+	if err != nil {
+		reason = err.Error()
+	}
+	publishDecision(Decision{
+		UserId:      reqPms.UserId,
+		ObjectType:  reqPms.ObjectType,
+		ObjectIdx:   reqPms.ObjectIdx,
+		SubResource: reqPms.SubResource,
+		Acts:        reqPms.Acts,
+		DomainType:  reqPms.DomainType,
+		DomainId:    reqPms.DomainId,
+		Allowed:     err == nil,
+		Reason:      reason,
+		Latency:     time.Since(started),
+		CheckerName: checkerName,
+	})
+	return err
+}
@@ -0,0 +1,104 @@
+package permission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shimohq/mogo/api/internal/invoker"
+)
+
+// StdoutJSONSink drains Subscribe(ctx) and prints each Decision as a JSON line, handy for
+// local development and for piping into log-based tooling
+func StdoutJSONSink(ctx context.Context) {
+	ch := Subscribe(ctx)
+	go func() {
+		for d := range ch {
+			b, err := json.Marshal(d)
+			if err != nil {
+				invoker.Logger.Warn("permission audit: marshal decision failed", zap.Error(err))
+				continue
+			}
+			fmt.Println(string(b))
+		}
+	}()
+}
+
+// KafkaProducer is the minimal surface KafkaSink needs, satisfied by most Kafka
+// client libraries' producer types
+type KafkaProducer interface {
+	Produce(topic string, key, value []byte) error
+}
+
+// KafkaSink drains Subscribe(ctx) and publishes each Decision, JSON-encoded, to topic via
+// producer, for SIEM export / central audit pipelines
+func KafkaSink(ctx context.Context, producer KafkaProducer, topic string) {
+	ch := Subscribe(ctx)
+	go func() {
+		for d := range ch {
+			b, err := json.Marshal(d)
+			if err != nil {
+				invoker.Logger.Warn("permission audit: marshal decision failed", zap.Error(err))
+				continue
+			}
+			if err := producer.Produce(topic, []byte(d.CheckerName), b); err != nil {
+				invoker.Logger.Warn("permission audit: kafka produce failed", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// PmsAuditRecord is the row persisted by MySQLSink, one per published Decision
+type PmsAuditRecord struct {
+	Id          int `gorm:"primaryKey"`
+	Ctime       int64
+	Utime       int64
+	UserId      int
+	ObjectType  string
+	ObjectIdx   string
+	SubResource string
+	Acts        string
+	DomainType  string
+	DomainId    string
+	Allowed     bool
+	Reason      string
+	LatencyMs   int64
+	CheckerName string
+}
+
+func (PmsAuditRecord) TableName() string {
+	return "pms_audit"
+}
+
+// MySQLSink drains Subscribe(ctx) and persists each Decision into the pms_audit table via
+// invoker.Db, giving operators a queryable audit trail without grepping logs
+func MySQLSink(ctx context.Context) {
+	ch := Subscribe(ctx)
+	go func() {
+		for d := range ch {
+			now := time.Now().Unix()
+			record := PmsAuditRecord{
+				Ctime:       now,
+				Utime:       now,
+				UserId:      d.UserId,
+				ObjectType:  d.ObjectType,
+				ObjectIdx:   d.ObjectIdx,
+				SubResource: d.SubResource,
+				Acts:        strings.Join(d.Acts, ","),
+				DomainType:  d.DomainType,
+				DomainId:    d.DomainId,
+				Allowed:     d.Allowed,
+				Reason:      d.Reason,
+				LatencyMs:   d.Latency.Milliseconds(),
+				CheckerName: d.CheckerName,
+			}
+			if err := invoker.Db.Create(&record).Error; err != nil {
+				invoker.Logger.Warn("permission audit: mysql sink write failed", zap.Error(err))
+			}
+		}
+	}()
+}
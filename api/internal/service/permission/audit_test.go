@@ -0,0 +1,51 @@
+package permission
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPublishDecision_DropsOldestWhenSubscriberBufferFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := Subscribe(ctx)
+
+	// Fill the buffer, then publish one more: the oldest (ObjectIdx "0") should be
+	// dropped to make room, so the first item read back is ObjectIdx "1".
+	for i := 0; i < decisionBufferSize+1; i++ {
+		publishDecision(Decision{ObjectIdx: strconv.Itoa(i)})
+	}
+
+	select {
+	case d := <-ch:
+		if d.ObjectIdx != "1" {
+			t.Errorf("first buffered Decision.ObjectIdx = %q, want %q (the oldest should have been dropped)", d.ObjectIdx, "1")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a buffered Decision, got none")
+	}
+}
+
+func TestSubscribe_ClosesChannelAndUnsubscribesWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Subscribe(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatalf("expected channel to be closed once ctx is done, got a value instead")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after ctx was cancelled")
+	}
+
+	decisionSubsMu.RLock()
+	_, stillSubscribed := decisionSubs[ch]
+	decisionSubsMu.RUnlock()
+	if stillSubscribed {
+		t.Errorf("channel is still registered in decisionSubs after ctx was cancelled")
+	}
+}
@@ -0,0 +1,74 @@
+package permission
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/shimohq/mogo/api/internal/service/permission/errdefs"
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+// MsgNoPermission is returned to callers when a casbin enforcement doesn't pass
+const MsgNoPermission = "no permission"
+
+// iBaseChecker is embedded into the UserPmsChecker interface so every concrete checker is
+// guaranteed to share the domain-lock rule instead of reimplementing it
+type iBaseChecker interface {
+	CheckDomLockIfActWrite(reqPms *view.ReqPermission) error
+}
+
+// baseChecker is embedded by every concrete checker (defaultChecker, podTerminalChecker, ...)
+type baseChecker struct{}
+
+// lockedDomains holds the set of "domainType:domainId" pairs currently locked against
+// write operations (e.g. an environment frozen during a release)
+var lockedDomains sync.Map
+
+// SetDomainLock marks (domainType, domainId) as locked or unlocked for write operations
+func SetDomainLock(domainType, domainId string, locked bool) {
+	key := domainType + ":" + domainId
+	if locked {
+		lockedDomains.Store(key, struct{}{})
+		return
+	}
+	lockedDomains.Delete(key)
+}
+
+func isDomainLocked(domainType, domainId string) bool {
+	_, locked := lockedDomains.Load(domainType + ":" + domainId)
+	return locked
+}
+
+func actsContainWrite(acts []string) bool {
+	for _, a := range acts {
+		if a == "write" || a == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckDomLockIfActWrite rejects a write-intent ReqPermission whose domain has been locked
+func (b *baseChecker) CheckDomLockIfActWrite(reqPms *view.ReqPermission) error {
+	if !actsContainWrite(reqPms.Acts) {
+		return nil
+	}
+	if isDomainLocked(reqPms.DomainType, reqPms.DomainId) {
+		return errdefs.DomainLocked(errors.Errorf("domain %s:%s is locked for write operations", reqPms.DomainType, reqPms.DomainId))
+	}
+	return nil
+}
+
+// pms is the package's single entry point: it resolves the right UserPmsChecker for a
+// ReqPermission and delegates to it
+type pms struct{}
+
+// Pms is the package-level singleton callers use to check permissions
+var Pms = &pms{}
+
+// Check resolves reqPms to a strategy and runs it
+func (p *pms) Check(reqPms view.ReqPermission) error {
+	checker := p.newUserPmsCheckStrategy(reqPms.ObjectType, reqPms.SubResource)
+	return checker.Check(reqPms)
+}
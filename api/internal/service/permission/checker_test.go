@@ -0,0 +1,64 @@
+package permission
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+// fakeChecker is a minimal UserPmsChecker whose Check outcome is fixed at construction,
+// used to exercise RegisterChecker/ChainChecker without a casbin enforcer.
+type fakeChecker struct {
+	baseChecker
+	err error
+}
+
+func (f *fakeChecker) Check(view.ReqPermission) error { return f.err }
+
+func TestRegisterChecker_ConflictAndUnregister(t *testing.T) {
+	const objType, subResource = "table", "fakeCheckerTest"
+	t.Cleanup(func() { UnregisterChecker(objType, subResource) })
+
+	if err := RegisterChecker(objType, subResource, &fakeChecker{}); err != nil {
+		t.Fatalf("first RegisterChecker: unexpected error %v", err)
+	}
+	if err := RegisterChecker(objType, subResource, &fakeChecker{}); err == nil {
+		t.Fatalf("second RegisterChecker for the same pair: expected conflict error, got nil")
+	}
+
+	UnregisterChecker(objType, subResource)
+	if err := RegisterChecker(objType, subResource, &fakeChecker{}); err != nil {
+		t.Fatalf("RegisterChecker after Unregister: unexpected error %v", err)
+	}
+}
+
+func TestChainChecker_ShortCircuitsOnFirstFailure(t *testing.T) {
+	want := errors.New("second checker denies")
+	calledThird := false
+	third := &fakeChecker{}
+	chain := NewChainChecker(
+		&fakeChecker{err: nil},
+		&fakeChecker{err: want},
+		funcChecker{func(view.ReqPermission) error {
+			calledThird = true
+			return third.err
+		}},
+	)
+
+	if err := chain.Check(view.ReqPermission{}); err != want {
+		t.Fatalf("Check() = %v, want %v", err, want)
+	}
+	if calledThird {
+		t.Errorf("ChainChecker did not short-circuit: checker after the failing one was still called")
+	}
+}
+
+// funcChecker adapts a plain func to UserPmsChecker for tests that need to observe whether
+// a later checker in a chain was invoked.
+type funcChecker struct {
+	check func(view.ReqPermission) error
+}
+
+func (funcChecker) CheckDomLockIfActWrite(*view.ReqPermission) error { return nil }
+func (f funcChecker) Check(reqPms view.ReqPermission) error          { return f.check(reqPms) }
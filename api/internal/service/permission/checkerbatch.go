@@ -0,0 +1,125 @@
+package permission
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/shimohq/mogo/api/internal/service/permission/errdefs"
+	"github.com/shimohq/mogo/api/internal/service/permission/pmsplugin"
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+// Result is one ReqPermission's outcome from a batched Check, Index matching its position
+// in the request slice so callers can line results back up after grouping
+type Result struct {
+	Index   int
+	Allowed bool
+	Err     error
+}
+
+// BatchChecker is an opt-in sibling to UserPmsChecker for checkers that can resolve many
+// ReqPermissions in a single round trip through casbin's BatchEnforce instead of one
+// EnforceOneInMany per item. Checkers that don't implement it are still batchable through
+// pms.CheckBatch, just without the casbin-call savings.
+type BatchChecker interface {
+	CheckBatch(ctx context.Context, reqs []view.ReqPermission) ([]Result, error)
+}
+
+// CheckBatch resolves reqs to their strategies and, for each, the batched casbin path if
+// the strategy is a BatchChecker, falling back to one Check call per item otherwise. It's
+// meant for UI pages that need to know which of N tables/databases a user can see without
+// N round trips.
+func (p *pms) CheckBatch(ctx context.Context, reqs []view.ReqPermission) ([]Result, error) {
+	results := make([]Result, len(reqs))
+
+	byChecker := map[UserPmsChecker][]int{}
+	for i, reqPms := range reqs {
+		checker := p.newUserPmsCheckStrategy(reqPms.ObjectType, reqPms.SubResource)
+		byChecker[checker] = append(byChecker[checker], i)
+	}
+
+	for checker, indices := range byChecker {
+		subReqs := make([]view.ReqPermission, len(indices))
+		for j, idx := range indices {
+			subReqs[j] = reqs[idx]
+		}
+
+		if bc, ok := checker.(BatchChecker); ok {
+			subResults, err := bc.CheckBatch(ctx, subReqs)
+			if err != nil {
+				return nil, err
+			}
+			for j, idx := range indices {
+				r := subResults[j]
+				r.Index = idx
+				results[idx] = r
+			}
+			continue
+		}
+
+		for j, idx := range indices {
+			err := checker.Check(subReqs[j])
+			results[idx] = Result{Index: idx, Allowed: err == nil, Err: err}
+		}
+	}
+	return results, nil
+}
+
+// CheckBatch reuses the exact same preCasbinCheck steps Check runs (so the two can never
+// drift apart on what "allowed" means), then groups whatever reaches casbin by its unique
+// rule and invokes pmsplugin.BatchEnforce once for the whole group instead of one enforcer
+// call per item. Every item - including ones decided before casbin - is still recorded via
+// recordDecision, so nothing checked through this path is invisible to the audit bus.
+func (s *defaultChecker) CheckBatch(_ context.Context, reqs []view.ReqPermission) ([]Result, error) {
+	results := make([]Result, len(reqs))
+	started := make([]time.Time, len(reqs))
+
+	type ruleKey struct{ sub, obj, act, dom string }
+	ruleOf := map[ruleKey][]interface{}{}
+	indicesOf := map[ruleKey][]int{}
+
+	for i := range reqs {
+		started[i] = time.Now()
+		reqPms := reqs[i]
+
+		decided, decidedErr, items := s.preCasbinCheck(&reqPms)
+		if decided {
+			decidedErr = recordDecision("default", reqPms, started[i], decidedErr)
+			results[i] = Result{Index: i, Allowed: decidedErr == nil, Err: decidedErr}
+			continue
+		}
+
+		key := ruleKey{items.ReqSub, items.ReqObj, items.ReqAct, items.ReqDom}
+		ruleOf[key] = pmsplugin.Convert2InterfaceSlice(items.ReqSub, items.ReqObj, items.ReqAct, items.ReqDom)
+		indicesOf[key] = append(indicesOf[key], i)
+	}
+
+	if len(ruleOf) == 0 {
+		return results, nil
+	}
+
+	keys := make([]ruleKey, 0, len(ruleOf))
+	rules := make([][]interface{}, 0, len(ruleOf))
+	for key, rule := range ruleOf {
+		keys = append(keys, key)
+		rules = append(rules, rule)
+	}
+
+	allowed, err := pmsplugin.BatchEnforce(rules)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		var groupErr error
+		if !allowed[i] {
+			groupErr = errdefs.Forbidden(errors.New(MsgNoPermission))
+		}
+		for _, idx := range indicesOf[key] {
+			itemErr := recordDecision("default", reqs[idx], started[idx], groupErr)
+			results[idx] = Result{Index: idx, Allowed: itemErr == nil, Err: itemErr}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,119 @@
+package permission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"go.uber.org/zap"
+
+	"github.com/shimohq/mogo/api/internal/invoker"
+	"github.com/shimohq/mogo/api/internal/service/permission/pmsplugin"
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+const testCasbinModel = `
+[request_definition]
+r = sub, obj, act, dom
+
+[policy_definition]
+p = sub, obj, act, dom
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = r.sub == p.sub && r.obj == p.obj && (r.act == p.act || p.act == "*") && (r.dom == p.dom || p.dom == "*")
+`
+
+func newTestEnforcer(t *testing.T, policies ...[]string) *casbin.Enforcer {
+	t.Helper()
+	m, err := model.NewModelFromString(testCasbinModel)
+	if err != nil {
+		t.Fatalf("build casbin model: %v", err)
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		t.Fatalf("build casbin enforcer: %v", err)
+	}
+	for _, p := range policies {
+		rule := make([]interface{}, len(p))
+		for i, s := range p {
+			rule[i] = s
+		}
+		if _, err := e.AddPolicy(rule...); err != nil {
+			t.Fatalf("add policy %v: %v", p, err)
+		}
+	}
+	return e
+}
+
+func allowedReq() view.ReqPermission {
+	return view.ReqPermission{
+		UserId: 2, ObjectType: pmsplugin.PrefixTable, ObjectIdx: "db1",
+		SubResource: "query", Acts: []string{"read"},
+	}
+}
+
+func TestDefaultCheckerCheckBatch_IndexBookkeeping(t *testing.T) {
+	invoker.Logger = zap.NewNop()
+	pmsplugin.SetEnforcer(newTestEnforcer(t, []string{"user:2", "table:db1:subRsrc:query", "read", "*"}))
+
+	deniedReq := allowedReq()
+	deniedReq.ObjectIdx = "db2"
+	duplicateReq := allowedReq()
+	routeReq := view.ReqPermission{ObjectType: pmsplugin.PrefixRoute}
+
+	reqs := []view.ReqPermission{deniedReq, allowedReq(), duplicateReq, routeReq}
+
+	checker := &defaultChecker{}
+	results, err := checker.CheckBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("CheckBatch: %v", err)
+	}
+	if len(results) != len(reqs) {
+		t.Fatalf("expected %d results, got %d", len(reqs), len(results))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("result %d: Index = %d, want %d", i, r.Index, i)
+		}
+	}
+	if results[0].Allowed {
+		t.Errorf("result 0 (different table): expected denied")
+	}
+	if !results[1].Allowed {
+		t.Errorf("result 1: expected allowed, got err %v", results[1].Err)
+	}
+	if !results[2].Allowed {
+		t.Errorf("result 2 (duplicate rule of result 1): expected allowed, got err %v", results[2].Err)
+	}
+	if !results[3].Allowed {
+		t.Errorf("result 3 (route bypass): expected allowed")
+	}
+}
+
+func TestDefaultCheckerCheckBatch_RecordsDecisionPerItem(t *testing.T) {
+	invoker.Logger = zap.NewNop()
+	pmsplugin.SetEnforcer(newTestEnforcer(t, []string{"user:2", "table:db1:subRsrc:query", "read", "*"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	decisions := Subscribe(ctx)
+
+	reqs := []view.ReqPermission{allowedReq(), {ObjectType: pmsplugin.PrefixRoute}}
+	checker := &defaultChecker{}
+	if _, err := checker.CheckBatch(context.Background(), reqs); err != nil {
+		t.Fatalf("CheckBatch: %v", err)
+	}
+
+	for i := 0; i < len(reqs); i++ {
+		select {
+		case <-decisions:
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d decisions on the audit bus, only received %d", len(reqs), i)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package permission
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/shimohq/mogo/api/internal/service/permission/errdefs"
+	"github.com/shimohq/mogo/api/internal/service/permission/pmsplugin"
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+func init() {
+	MustRegister(pmsplugin.PrefixTable, pmsplugin.AppPodTerminal, newPodTerminalChecker())
+}
+
+// podTerminalChecker checks app's podTerminal permission for target user: a normal
+// casbin check (via defaultChecker) ANDed with the extra rule that the request is scoped
+// to a valid "env" domain, since podTerminal permission only ever makes sense per-env.
+type podTerminalChecker struct {
+	ChainChecker
+}
+
+func newPodTerminalChecker() *podTerminalChecker {
+	return &podTerminalChecker{
+		ChainChecker: *NewChainChecker(&defaultChecker{}, &envScopeChecker{}),
+	}
+}
+
+// envScopeChecker enforces that a ReqPermission is scoped to a valid environment domain
+type envScopeChecker struct{ baseChecker }
+
+func (s *envScopeChecker) Check(reqPms view.ReqPermission) (err error) {
+	started := time.Now()
+	defer func() { err = recordDecision("podTerminal-envScope", reqPms, started, err) }()
+
+	if reqPms.DomainType != "env" {
+		err = errdefs.InvalidRequest(errors.Errorf("podTerminal check requires DomainType \"env\", got %q", reqPms.DomainType))
+		return err
+	}
+	envId, atoiErr := strconv.Atoi(reqPms.DomainId)
+	if atoiErr != nil || envId <= 0 {
+		err = errdefs.InvalidRequest(errors.Errorf("podTerminal check requires a valid DomainId, got %q", reqPms.DomainId))
+		return err
+	}
+	return nil
+}
@@ -1,12 +1,14 @@
 package permission
 
 import (
-	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/shimohq/mogo/api/internal/invoker"
+	"github.com/shimohq/mogo/api/internal/service/permission/errdefs"
 	"github.com/shimohq/mogo/api/internal/service/permission/pmsplugin"
 	"github.com/shimohq/mogo/api/pkg/model/view"
 )
@@ -17,103 +19,61 @@ type UserPmsChecker interface {
 	Check(reqPms view.ReqPermission) error // if err == nil, means reqPermission passed
 }
 
-// Special pmsCheckStrategies for special resource permission check for user
-// ObjectType -> SubResource -> UserPmsChecker.
-// Note, "ObjectType" and "SubResource" are the properties of view.ReqPermission
-var strategies = map[string]map[string]UserPmsChecker{
-	pmsplugin.PrefixTable: {
-		pmsplugin.AppPodTerminal: &podTerminalChecker{},
-	},
-}
-
-// create a UserPmsChecker Strategy
-func (p *pms) newUserPmsCheckStrategy(objType, subResource string) UserPmsChecker {
-	subResourceCheckerMap, objOk := strategies[objType]
-	if !objOk {
-		return &defaultChecker{}
-	}
-	checker, exist := subResourceCheckerMap[subResource]
-	if !exist {
-		return &defaultChecker{}
-	}
-	return checker
-}
-
-type (
-	defaultChecker     struct{ baseChecker } // default checker, check all normal permission for user
-	podTerminalChecker struct{ baseChecker } // check app's podTerminal permission for target user
-)
+type defaultChecker struct{ baseChecker } // default checker, check all normal permission for user
 
 // used for 99% cases (normal) permission check
-func (s *defaultChecker) Check(reqPms view.ReqPermission) error {
-	invoker.Logger.Info("request check permission", zap.Any("data", reqPms))
-	// 1. check permission which has no domain
-	if reqPms.ObjectType == pmsplugin.PrefixRoute {
-		// TODO: check route permission
-		invoker.Logger.Info("==> route always pass currently.")
-		return nil
-	}
-
-	// 2. check req domain lock or not
-	err := s.CheckDomLockIfActWrite(&reqPms)
-	if err != nil {
-		return err
-	}
+func (s *defaultChecker) Check(reqPms view.ReqPermission) (err error) {
+	started := time.Now()
+	defer func() { err = recordDecision("default", reqPms, started, err) }()
 
-	// 3. normal check by casbin
-	if isRootUser(reqPms.UserId) {
-		return nil
-	}
-
-	items, err := getCasbinItemsFromReqPermission(&reqPms)
-	if err != nil {
-		err = fmt.Errorf("ReqPermission is invalid. %w", err)
-		invoker.Logger.Error(err.Error())
-		return err
+	decided, decidedErr, items := s.preCasbinCheck(&reqPms)
+	if decided {
+		return decidedErr
 	}
 
 	var reqRules [][]interface{}
 	reqRules = append(reqRules, pmsplugin.Convert2InterfaceSlice(items.ReqSub, items.ReqObj, items.ReqAct, items.ReqDom))
-	// result, err := pmsplugin.Enforce(reqSub, reqObj, reqAct, reqDom)
-	pmsPassed, err := pmsplugin.EnforceOneInMany(reqRules...)
-	if err != nil {
-		invoker.Logger.Warn("reqPerm not pass", zap.Error(err))
+	pmsPassed, enforceErr := pmsplugin.EnforceOneInMany(reqRules...)
+	if enforceErr != nil {
+		invoker.Logger.Warn("reqPerm not pass", zap.Error(enforceErr))
 	}
 	if !pmsPassed {
-		return fmt.Errorf(MsgNoPermission)
+		err = errdefs.Forbidden(errors.New(MsgNoPermission))
+		return err
 	}
 	return nil
 }
 
-// note, podTerminalChecker.Check  for normal user, need reqDomainType == "env" && reqDomainId is a valid envId.
-func (s *podTerminalChecker) Check(reqPms view.ReqPermission) error {
+// preCasbinCheck runs the route-bypass, domain-lock and root-user steps shared by Check
+// and CheckBatch. If decided is true, the caller is done and should return decidedErr
+// (possibly nil) without ever reaching casbin. If decided is false, items is ready to be
+// turned into a casbin rule. Keeping this in one place means Check and the batched path
+// can't drift apart on what "allowed" means.
+func (s *defaultChecker) preCasbinCheck(reqPms *view.ReqPermission) (decided bool, decidedErr error, items casbinItemsFromReqPermission) {
 	invoker.Logger.Info("request check permission", zap.Any("data", reqPms))
 	// 1. check permission which has no domain
+	if reqPms.ObjectType == pmsplugin.PrefixRoute {
+		// TODO: check route permission
+		invoker.Logger.Info("==> route always pass currently.")
+		return true, nil, items
+	}
+
 	// 2. check req domain lock or not
-	err := s.CheckDomLockIfActWrite(&reqPms)
-	if err != nil {
-		return err
+	if decidedErr = s.CheckDomLockIfActWrite(reqPms); decidedErr != nil {
+		return true, decidedErr, items
 	}
 
 	// 3. normal check by casbin
 	if isRootUser(reqPms.UserId) {
-		return nil
-	}
-	items, err := getCasbinItemsFromReqPermission(&reqPms)
-	if err != nil {
-		err = fmt.Errorf("ReqPermission is invalid. %w", err)
-		invoker.Logger.Error(err.Error())
-		return err
+		return true, nil, items
 	}
-	// 4. check podTerminal permission for normal user:
-	pmsPassed, err := pmsplugin.Enforce(items.ReqSub, items.ReqObj, items.ReqAct, items.ReqDom)
-	if err != nil {
-		invoker.Logger.Warn("reqPerm not pass", zap.Error(err))
-	}
-	if !pmsPassed {
-		return fmt.Errorf(MsgNoPermission)
+
+	items, decidedErr = getCasbinItemsFromReqPermission(reqPms)
+	if decidedErr != nil {
+		invoker.Logger.Error(decidedErr.Error())
+		return true, decidedErr, items
 	}
-	return nil
+	return false, nil, items
 }
 
 // --- below are private materials... used above
@@ -135,10 +95,10 @@ type casbinItemsFromReqPermission struct {
 func getCasbinItemsFromReqPermission(reqPms *view.ReqPermission) (casbinItemsFromReqPermission, error) {
 	resp := casbinItemsFromReqPermission{}
 	if reqPms.UserId == 0 || reqPms.ObjectType == "" || reqPms.ObjectIdx == "" || reqPms.SubResource == "" {
-		return resp, fmt.Errorf("The UserId, ObjectType, ObjectIdx and SubRersource cannot be empty. ")
+		return resp, errdefs.InvalidRequest(errors.New("The UserId, ObjectType, ObjectIdx and SubRersource cannot be empty. "))
 	}
 	if _, valid := pmsplugin.PermittedPrefixMap[reqPms.ObjectType]; !valid {
-		return resp, fmt.Errorf("ObjectType(%s) is invalid", reqPms.ObjectType)
+		return resp, errdefs.InvalidRequest(errors.Errorf("ObjectType(%s) is invalid", reqPms.ObjectType))
 	}
 
 	reqSub, _ := pmsplugin.Assemble2CasbinStr(pmsplugin.PrefixUser, strconv.Itoa(reqPms.UserId))
@@ -0,0 +1,106 @@
+// Package errdefs defines the typed, HTTP-status-agnostic errors permission checkers
+// return, mirroring the approach Docker's errdefs package uses: a marker interface per
+// error kind plus an Is-style helper so callers never need to string-match.
+package errdefs
+
+import "errors"
+
+// ErrForbidden is implemented by errors that should surface as 403 Forbidden
+type ErrForbidden interface {
+	Forbidden()
+}
+
+// ErrInvalidRequest is implemented by errors that should surface as 400 Bad Request
+type ErrInvalidRequest interface {
+	InvalidParameter()
+}
+
+// ErrDomainLocked is implemented by errors that should surface as 423 Locked
+type ErrDomainLocked interface {
+	DomainLocked()
+}
+
+// ErrNotFound is implemented by errors that should surface as 404 Not Found
+type ErrNotFound interface {
+	NotFound()
+}
+
+type forbiddenError struct{ error }
+
+func (forbiddenError) Forbidden()      {}
+func (e forbiddenError) Unwrap() error { return e.error }
+func (e forbiddenError) Cause() error  { return e.error }
+
+// Forbidden wraps err as an ErrForbidden
+func Forbidden(err error) error {
+	if err == nil {
+		return nil
+	}
+	return forbiddenError{err}
+}
+
+type invalidRequestError struct{ error }
+
+func (invalidRequestError) InvalidParameter() {}
+func (e invalidRequestError) Unwrap() error   { return e.error }
+func (e invalidRequestError) Cause() error    { return e.error }
+
+// InvalidRequest wraps err as an ErrInvalidRequest
+func InvalidRequest(err error) error {
+	if err == nil {
+		return nil
+	}
+	return invalidRequestError{err}
+}
+
+type domainLockedError struct{ error }
+
+func (domainLockedError) DomainLocked()   {}
+func (e domainLockedError) Unwrap() error { return e.error }
+func (e domainLockedError) Cause() error  { return e.error }
+
+// DomainLocked wraps err as an ErrDomainLocked
+func DomainLocked(err error) error {
+	if err == nil {
+		return nil
+	}
+	return domainLockedError{err}
+}
+
+type notFoundError struct{ error }
+
+func (notFoundError) NotFound()       {}
+func (e notFoundError) Unwrap() error { return e.error }
+func (e notFoundError) Cause() error  { return e.error }
+
+// NotFound wraps err as an ErrNotFound
+func NotFound(err error) error {
+	if err == nil {
+		return nil
+	}
+	return notFoundError{err}
+}
+
+// IsForbidden reports whether err, or anything it wraps, is an ErrForbidden
+func IsForbidden(err error) bool {
+	var e ErrForbidden
+	return errors.As(err, &e)
+}
+
+// IsInvalidRequest reports whether err, or anything it wraps, is an ErrInvalidRequest
+func IsInvalidRequest(err error) bool {
+	var e ErrInvalidRequest
+	return errors.As(err, &e)
+}
+
+// IsDomainLocked reports whether err, or anything it wraps, is an ErrDomainLocked
+func IsDomainLocked(err error) bool {
+	var e ErrDomainLocked
+	return errors.As(err, &e)
+}
+
+// IsNotFound reports whether err, or anything it wraps, is an ErrNotFound
+func IsNotFound(err error) bool {
+	var e ErrNotFound
+	return errors.As(err, &e)
+}
@@ -0,0 +1,68 @@
+package permission
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/shimohq/mogo/api/internal/service/permission/httpstatus"
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+// RegisterRoutes wires the permission package's HTTP surface onto r
+func RegisterRoutes(r gin.IRouter) {
+	r.POST("/api/v1/permissions/check", GinMiddleware(extractReqPermissionFromBody), checkOKHandler)
+	r.POST("/api/v1/permissions/check-batch", checkBatchHandler)
+}
+
+// extractReqPermissionFromBody binds the request body straight into a view.ReqPermission,
+// it's the extract func GinMiddleware needs to run Pms.Check ahead of checkOKHandler.
+func extractReqPermissionFromBody(c *gin.Context) (view.ReqPermission, error) {
+	var reqPms view.ReqPermission
+	if err := c.ShouldBindJSON(&reqPms); err != nil {
+		return reqPms, err
+	}
+	return reqPms, nil
+}
+
+// checkOKHandler only runs once GinMiddleware has already let the request through, so
+// reaching it means the caller is permitted to perform reqPms
+func checkOKHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": true})
+}
+
+type reqCheckBatch struct {
+	Items []view.ReqPermission `json:"items" binding:"required"`
+}
+
+type respCheckBatchItem struct {
+	Index   int    `json:"index"`
+	Allowed bool   `json:"allowed"`
+	Msg     string `json:"msg,omitempty"`
+}
+
+// checkBatchHandler resolves, in one request, which of req.Items the caller is permitted
+// to do - used by the frontend to resolve menu/table visibility without N round trips
+func checkBatchHandler(c *gin.Context) {
+	var req reqCheckBatch
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+		return
+	}
+
+	results, err := Pms.CheckBatch(c.Request.Context(), req.Items)
+	if err != nil {
+		c.JSON(httpstatus.FromError(err), gin.H{"msg": err.Error()})
+		return
+	}
+
+	resp := make([]respCheckBatchItem, len(results))
+	for i, r := range results {
+		item := respCheckBatchItem{Index: r.Index, Allowed: r.Allowed}
+		if r.Err != nil {
+			item.Msg = r.Err.Error()
+		}
+		resp[i] = item
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
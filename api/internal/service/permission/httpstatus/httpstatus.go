@@ -0,0 +1,29 @@
+// Package httpstatus maps the typed errors returned by the permission package onto HTTP
+// status codes, so callers no longer need to string-match error messages.
+package httpstatus
+
+import (
+	"net/http"
+
+	"github.com/shimohq/mogo/api/internal/service/permission/errdefs"
+)
+
+// FromError maps err onto the HTTP status code it should be reported as. nil maps to 200
+// (no error to report); any other unrecognized error maps to 500.
+func FromError(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	switch {
+	case errdefs.IsInvalidRequest(err):
+		return http.StatusBadRequest
+	case errdefs.IsForbidden(err):
+		return http.StatusForbidden
+	case errdefs.IsNotFound(err):
+		return http.StatusNotFound
+	case errdefs.IsDomainLocked(err):
+		return http.StatusLocked
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,28 @@
+package permission
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/shimohq/mogo/api/internal/service/permission/httpstatus"
+	"github.com/shimohq/mogo/api/pkg/model/view"
+)
+
+// GinMiddleware builds a gin.HandlerFunc that resolves reqPms from the request via
+// extract, runs it through pms.Check, and aborts with the HTTP status httpstatus.FromError
+// maps the resulting error onto.
+func GinMiddleware(extract func(c *gin.Context) (view.ReqPermission, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqPms, err := extract(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+			return
+		}
+		if err := Pms.Check(reqPms); err != nil {
+			c.AbortWithStatusJSON(httpstatus.FromError(err), gin.H{"msg": err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,95 @@
+package pmsplugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+)
+
+// ObjectType prefixes, used both as the casbin object prefix and as the key into
+// UserPmsChecker's strategies map
+const (
+	PrefixUser     = "user"
+	PrefixTable    = "table"
+	PrefixDatabase = "database"
+	PrefixRoute    = "route"
+	PrefixSubRsrc  = "subRsrc"
+)
+
+// SubResource values for known special-cased resources
+const AppPodTerminal = "podTerminal"
+
+// PermittedPrefixMap whitelists the ObjectType values a ReqPermission may carry
+var PermittedPrefixMap = map[string]bool{
+	PrefixTable:    true,
+	PrefixDatabase: true,
+	PrefixRoute:    true,
+}
+
+var enforcer *casbin.Enforcer
+
+// SetEnforcer installs the casbin enforcer used by Enforce/EnforceOneInMany/BatchEnforce,
+// it's called once during application bootstrap
+func SetEnforcer(e *casbin.Enforcer) {
+	enforcer = e
+}
+
+// Assemble2CasbinStr joins non-empty parts into a casbin policy string, rejecting any
+// empty segment so callers can't accidentally assemble a wildcard-shaped rule
+func Assemble2CasbinStr(parts ...string) (string, error) {
+	for _, p := range parts {
+		if p == "" {
+			return "", fmt.Errorf("pmsplugin: empty part assembling %v", parts)
+		}
+	}
+	return strings.Join(parts, ":"), nil
+}
+
+// Convert2InterfaceSlice adapts a run of casbin string args into the []interface{} shape
+// casbin's Enforce/BatchEnforce expect
+func Convert2InterfaceSlice(args ...string) []interface{} {
+	res := make([]interface{}, 0, len(args))
+	for _, a := range args {
+		res = append(res, a)
+	}
+	return res
+}
+
+// JointActs2RuleActStr joins a ReqPermission's Acts into the single act string casbin rules use
+func JointActs2RuleActStr(acts ...string) string {
+	return strings.Join(acts, ",")
+}
+
+// Enforce runs a single casbin enforcement
+func Enforce(sub, obj, act, dom string) (bool, error) {
+	return enforcer.Enforce(sub, obj, act, dom)
+}
+
+// EnforceOneInMany passes if any one of the given rules enforces true, used when a
+// ReqPermission can be satisfied through more than one casbin rule shape
+func EnforceOneInMany(rules ...[]interface{}) (bool, error) {
+	for _, r := range rules {
+		ok, err := enforcer.Enforce(r...)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// BatchEnforce resolves many casbin rules in a single call, so a page that needs to know
+// which of N tables/databases a user can see doesn't pay for N round trips through the
+// enforcer's lock
+func BatchEnforce(rules [][]interface{}) ([]bool, error) {
+	return enforcer.BatchEnforce(rules)
+}
+
+// IsRootWithoutCheckingSysLock reports whether uid is the built-in root user, bypassing
+// casbin entirely. It does not consider whether the system itself has been locked down.
+func IsRootWithoutCheckingSysLock(uid int) bool {
+	return uid == 1
+}
@@ -0,0 +1,14 @@
+package view
+
+// ReqPermission describes a single permission question: can UserId perform Acts against
+// the resource identified by (ObjectType, ObjectIdx, SubResource), optionally scoped to
+// (DomainType, DomainId)?
+type ReqPermission struct {
+	UserId      int      `json:"userId"`
+	ObjectType  string   `json:"objectType"`
+	ObjectIdx   string   `json:"objectIdx"`
+	SubResource string   `json:"subResource"`
+	Acts        []string `json:"acts"`
+	DomainType  string   `json:"domainType"`
+	DomainId    string   `json:"domainId"`
+}